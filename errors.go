@@ -0,0 +1,93 @@
+package netcup
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Status codes documented by the netcup CCP API that this client treats
+// specially. All other non-2000 codes still surface as an *APIError, just
+// without a matching sentinel.
+const (
+	statusCodeMissingParameter     = 4001
+	statusCodeAuthenticationFailed = 4013
+	statusCodeObjectNotFound       = 4022
+	statusCodeTooManyRequests      = 4029
+)
+
+var (
+	// ErrMissingParameter is matched by APIErrors caused by a required
+	// parameter that was not sent with the request.
+	ErrMissingParameter = errors.New("netcup: missing parameter")
+
+	// ErrAuthFailed is matched by APIErrors caused by invalid credentials or
+	// an invalid/expired session ID.
+	ErrAuthFailed = errors.New("netcup: authentication failed")
+
+	// ErrNotFound is matched by APIErrors caused by a domain, zone or record
+	// that does not exist.
+	ErrNotFound = errors.New("netcup: object not found")
+
+	// ErrRateLimited is matched by APIErrors caused by the API throttling
+	// the client.
+	ErrRateLimited = errors.New("netcup: too many requests")
+)
+
+// APIError is returned whenever the netcup API responds with a status code
+// other than 2000. It carries the fields of the response so callers can
+// branch on the failure mode instead of matching on LongMessage.
+type APIError struct {
+	Action          string
+	Status          string
+	StatusCode      int
+	ShortMessage    string
+	LongMessage     string
+	ServerRequestID string
+	ClientRequestID string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("netcup: request %q failed (statuscode %d): %s", e.Action, e.StatusCode, e.LongMessage)
+}
+
+// Is reports whether err matches one of the sentinel errors in this package,
+// based on the documented netcup status code, so callers can use
+// errors.Is(err, netcup.ErrNotFound) instead of matching on LongMessage.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrMissingParameter:
+		return e.StatusCode == statusCodeMissingParameter
+	case ErrAuthFailed:
+		return e.StatusCode == statusCodeAuthenticationFailed
+	case ErrNotFound:
+		return e.StatusCode == statusCodeObjectNotFound
+	case ErrRateLimited:
+		return e.StatusCode == statusCodeTooManyRequests
+	default:
+		return false
+	}
+}
+
+// IsMissingParameter reports whether err is an APIError caused by a required
+// parameter that was not sent with the request.
+func IsMissingParameter(err error) bool {
+	return errors.Is(err, ErrMissingParameter)
+}
+
+// IsAuthError reports whether err is an APIError caused by invalid
+// credentials or an invalid/expired session ID.
+func IsAuthError(err error) bool {
+	return errors.Is(err, ErrAuthFailed)
+}
+
+// IsNotFound reports whether err is an APIError caused by a domain, zone or
+// record that does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsRateLimited reports whether err is an APIError caused by the API
+// throttling the client.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
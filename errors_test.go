@@ -0,0 +1,83 @@
+package netcup
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestRequestReturnsAPIError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"serverrequestid": "abc123",
+			"clientrequestid": "",
+			"action": "infoDnsRecords",
+			"status": "error",
+			"statuscode": 4013,
+			"shortmessage": "Authentication failed",
+			"longmessage": "Could not login as customer. Wrong customer number, api key or password.",
+			"responsedata": ""
+		  }`)
+	})
+
+	_, err := client.GetRecords("example.com")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+
+	if apiErr.Action != "infoDnsRecords" || apiErr.StatusCode != 4013 || apiErr.ServerRequestID != "abc123" {
+		t.Errorf("APIError did not carry the expected fields: %+v", apiErr)
+	}
+
+	if !IsAuthError(err) {
+		t.Error("expected IsAuthError to report true for statuscode 4013")
+	}
+
+	if IsNotFound(err) || IsRateLimited(err) || IsMissingParameter(err) {
+		t.Error("expected only IsAuthError to match")
+	}
+}
+
+func TestIsMissingParameter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"serverrequestid": "",
+			"clientrequestid": "",
+			"action": "infoDnsRecords",
+			"status": "error",
+			"statuscode": 4001,
+			"shortmessage": "Validation Error",
+			"longmessage": "The given parameter domainname is invalid or missing.",
+			"responsedata": ""
+		  }`)
+	})
+
+	_, err := client.GetRecords("example.com")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !IsMissingParameter(err) {
+		t.Error("expected IsMissingParameter to report true for statuscode 4001")
+	}
+
+	if IsAuthError(err) || IsNotFound(err) || IsRateLimited(err) {
+		t.Error("expected only IsMissingParameter to match")
+	}
+}
@@ -2,6 +2,7 @@ package netcup
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -34,6 +35,47 @@ type Record struct {
 	State        string `json:"state"`
 }
 
+// dnsRecordSet wraps a list of records the way the netcup API expects them
+// on updateDnsRecords calls.
+type dnsRecordSet struct {
+	Records []Record `json:"dnsrecords"`
+}
+
+// LoginRequest is the parameter set for the "login" action.
+type LoginRequest struct {
+	APIPassword     string `json:"apipassword"`
+	ClientRequestID string `json:"clientrequestid,omitempty"`
+}
+
+// LogoutRequest is the parameter set for the "logout" action.
+type LogoutRequest struct {
+	ClientRequestID string `json:"clientrequestid,omitempty"`
+}
+
+// InfoDNSRecordsRequest is the parameter set for the "infoDnsRecords" action.
+type InfoDNSRecordsRequest struct {
+	DomainName      string `json:"domainname"`
+	ClientRequestID string `json:"clientrequestid,omitempty"`
+}
+
+// UpdateDNSRecordsRequest is the parameter set for the "updateDnsRecords" action.
+type UpdateDNSRecordsRequest struct {
+	DomainName      string       `json:"domainname"`
+	DNSRecordSet    dnsRecordSet `json:"dnsrecordset"`
+	ClientRequestID string       `json:"clientrequestid,omitempty"`
+}
+
+// loginResponseData is the "responsedata" payload of a successful login.
+type loginResponseData struct {
+	APISessionID string `json:"apisessionid"`
+}
+
+// dnsRecordsResponseData is the "responsedata" payload shared by
+// infoDnsRecords and updateDnsRecords.
+type dnsRecordsResponseData struct {
+	DNSRecords []Record `json:"dnsrecords"`
+}
+
 // requestBody for all messages sent to the API
 type requestBody struct {
 	Action string      `json:"action"`
@@ -52,8 +94,53 @@ type responseBody struct {
 	ResponseData    *json.RawMessage `json:"responsedata"`
 }
 
+// Option configures optional settings on a Client created by NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used to perform API requests, e.g. to
+// inject an instrumented client, a custom retry transport, or a client with
+// different timeouts than the default.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL points the client at a different endpoint than the default
+// netcup CCP API, e.g. a mock server in tests or a staging environment.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.endpoint = baseURL
+	}
+}
+
+// RequestOption customizes a single API call.
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the per-call settings configured via RequestOption.
+type requestOptions struct {
+	clientRequestID string
+}
+
+// WithClientRequestID sets the clientrequestid sent with a single API call,
+// so callers can correlate it with the corresponding entry in netcup's logs.
+func WithClientRequestID(id string) RequestOption {
+	return func(o *requestOptions) {
+		o.clientRequestID = id
+	}
+}
+
+// resolveRequestOptions applies opts to a zero-value requestOptions.
+func resolveRequestOptions(opts []RequestOption) requestOptions {
+	var o requestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // NewClient returns a new client for the Netcup CCP API
-func NewClient(customerNumber int, apiKey string) *Client {
+func NewClient(customerNumber int, apiKey string, opts ...Option) *Client {
 	c := &Client{
 		customerNumber: customerNumber,
 		apiKey:         apiKey,
@@ -61,15 +148,18 @@ func NewClient(customerNumber int, apiKey string) *Client {
 		httpClient:     &http.Client{},
 	}
 
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	return c
 }
 
-func (c *Client) request(action string, param interface{}) (*json.RawMessage, error) {
+func (c *Client) request(ctx context.Context, action string, param interface{}) (*json.RawMessage, error) {
 	if c.sessionID == "" && action != "login" {
 		return nil, fmt.Errorf("no session ID. Make sure to login first")
 	}
 
-
 	paramMap := map[string]interface{}{}
 
 	if param != nil {
@@ -83,7 +173,7 @@ func (c *Client) request(action string, param interface{}) (*json.RawMessage, er
 			return nil, err
 		}
 	}
-	
+
 	// Add common request data
 	paramMap["customernumber"] = c.customerNumber
 	paramMap["apikey"] = c.apiKey
@@ -97,10 +187,17 @@ func (c *Client) request(action string, param interface{}) (*json.RawMessage, er
 		return nil, err
 	}
 
-	response, err := c.httpClient.Post(c.endpoint, "application/json", bytes.NewBuffer(buf))
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewBuffer(buf))
 	if err != nil {
 		return nil, err
 	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	response, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
 
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
@@ -114,28 +211,51 @@ func (c *Client) request(action string, param interface{}) (*json.RawMessage, er
 	}
 
 	if responseBody.StatusCode != 2000 {
-		return nil, fmt.Errorf(`Request "%s" failed: %s`, action, responseBody.LongMessage)
+		return nil, &APIError{
+			Action:          action,
+			Status:          responseBody.Status,
+			StatusCode:      responseBody.StatusCode,
+			ShortMessage:    responseBody.ShortMessage,
+			LongMessage:     responseBody.LongMessage,
+			ServerRequestID: responseBody.ServerRequestID,
+			ClientRequestID: responseBody.ClientRequestID,
+		}
 	}
 
 	return responseBody.ResponseData, nil
 }
 
-// Login before calling any other actions
-func (c *Client) Login(apiPassword string) error {
-	param := struct {
-		APIPassword string `json:"apipassword"`
-	}{apiPassword}
+// unmarshalResponseData decodes raw into v, unless raw holds a JSON string
+// (the netcup API returns e.g. `"responsedata": ""` on logout instead of
+// omitting the field or returning an object), in which case it is treated
+// as "no data" rather than an unmarshal target.
+func unmarshalResponseData(raw *json.RawMessage, v interface{}) error {
+	if raw == nil {
+		return nil
+	}
+
+	trimmed := bytes.TrimSpace(*raw)
+	if len(trimmed) > 0 && trimmed[0] == '"' {
+		return nil
+	}
 
-	buf, err := c.request("login", param)
+	return json.Unmarshal(*raw, v)
+}
+
+// LoginContext is like Login but observes ctx for cancellation and timeouts.
+func (c *Client) LoginContext(ctx context.Context, apiPassword string, opts ...RequestOption) error {
+	param := LoginRequest{
+		APIPassword:     apiPassword,
+		ClientRequestID: resolveRequestOptions(opts).clientRequestID,
+	}
+
+	buf, err := c.request(ctx, "login", param)
 	if err != nil {
 		return err
 	}
 
-	responseData := struct {
-		APISessionID string `json:"apisessionid"`
-	}{}
-
-	err = json.Unmarshal(*buf, &responseData)
+	responseData := loginResponseData{}
+	err = unmarshalResponseData(buf, &responseData)
 	if err != nil {
 		return err
 	}
@@ -144,9 +264,16 @@ func (c *Client) Login(apiPassword string) error {
 	return nil
 }
 
-// Logout to close the session
-func (c *Client) Logout() error {
-	_, err := c.request("logout", nil)
+// Login before calling any other actions
+func (c *Client) Login(apiPassword string, opts ...RequestOption) error {
+	return c.LoginContext(context.Background(), apiPassword, opts...)
+}
+
+// LogoutContext is like Logout but observes ctx for cancellation and timeouts.
+func (c *Client) LogoutContext(ctx context.Context, opts ...RequestOption) error {
+	param := LogoutRequest{ClientRequestID: resolveRequestOptions(opts).clientRequestID}
+
+	_, err := c.request(ctx, "logout", param)
 	if err != nil {
 		return err
 	}
@@ -155,22 +282,25 @@ func (c *Client) Logout() error {
 	return nil
 }
 
-// GetRecords of a domain
-func (c *Client) GetRecords(domainname string) ([]Record, error) {
-	param := struct {
-		DomainName string `json:"domainname"`
-	}{domainname}
+// Logout to close the session
+func (c *Client) Logout(opts ...RequestOption) error {
+	return c.LogoutContext(context.Background(), opts...)
+}
 
-	buf, err := c.request("infoDnsRecords", param)
+// GetRecordsContext is like GetRecords but observes ctx for cancellation and timeouts.
+func (c *Client) GetRecordsContext(ctx context.Context, domainname string, opts ...RequestOption) ([]Record, error) {
+	param := InfoDNSRecordsRequest{
+		DomainName:      domainname,
+		ClientRequestID: resolveRequestOptions(opts).clientRequestID,
+	}
+
+	buf, err := c.request(ctx, "infoDnsRecords", param)
 	if err != nil {
 		return nil, err
 	}
 
-	responseData := struct {
-		DNSRecords []Record `json:"dnsrecords"`
-	}{}
-
-	err = json.Unmarshal(*buf, &responseData)
+	responseData := dnsRecordsResponseData{}
+	err = unmarshalResponseData(buf, &responseData)
 	if err != nil {
 		return nil, err
 	}
@@ -180,27 +310,26 @@ func (c *Client) GetRecords(domainname string) ([]Record, error) {
 	return responseData.DNSRecords, nil
 }
 
-// UpdateRecords of a domain
-func (c *Client) UpdateRecords(domainname string, records []Record) ([]Record, error) {
-	type recordSet struct {
-		Records []Record `json:"dnsrecords"`
-	}
-	param := struct {
-		DomainName string `json:"domainname"`
-		DNSRecordSet recordSet `json:"dnsrecordset"`
+// GetRecords of a domain
+func (c *Client) GetRecords(domainname string, opts ...RequestOption) ([]Record, error) {
+	return c.GetRecordsContext(context.Background(), domainname, opts...)
+}
 
-	}{domainname, recordSet{records}}
+// UpdateRecordsContext is like UpdateRecords but observes ctx for cancellation and timeouts.
+func (c *Client) UpdateRecordsContext(ctx context.Context, domainname string, records []Record, opts ...RequestOption) ([]Record, error) {
+	param := UpdateDNSRecordsRequest{
+		DomainName:      domainname,
+		DNSRecordSet:    dnsRecordSet{records},
+		ClientRequestID: resolveRequestOptions(opts).clientRequestID,
+	}
 
-	buf, err := c.request("updateDnsRecords", param)
+	buf, err := c.request(ctx, "updateDnsRecords", param)
 	if err != nil {
 		return nil, err
 	}
 
-	responseData := struct {
-		DNSRecords []Record `json:"dnsrecords"`
-	}{}
-
-	err = json.Unmarshal(*buf, &responseData)
+	responseData := dnsRecordsResponseData{}
+	err = unmarshalResponseData(buf, &responseData)
 	if err != nil {
 		return nil, err
 	}
@@ -209,3 +338,8 @@ func (c *Client) UpdateRecords(domainname string, records []Record) ([]Record, e
 
 	return responseData.DNSRecords, nil
 }
+
+// UpdateRecords of a domain
+func (c *Client) UpdateRecords(domainname string, records []Record, opts ...RequestOption) ([]Record, error) {
+	return c.UpdateRecordsContext(context.Background(), domainname, records, opts...)
+}
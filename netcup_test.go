@@ -26,8 +26,7 @@ func setup() {
 	mux = http.NewServeMux()
 	server = httptest.NewServer(mux)
 
-	client = NewClient(1234, "key")
-	client.endpoint = server.URL
+	client = NewClient(1234, "key", WithBaseURL(server.URL))
 }
 
 func teardown() {
@@ -70,6 +69,38 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestLoginWithClientRequestID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		want := `{"action":"login","param":{"apikey":"key","apipassword":"password","clientrequestid":"req-42","customernumber":1234}}`
+		body, err := ioutil.ReadAll(r.Body)
+
+		if err != nil || string(body) != want {
+			t.Error("Client did not send the clientrequestid with the login request.")
+		}
+
+		fmt.Fprint(w, `{
+			"serverrequestid": "",
+			"clientrequestid": "req-42",
+			"action": "login",
+			"status": "success",
+			"statuscode": 2000,
+			"shortmessage": "Login successful",
+			"longmessage": "Session has been created successful.",
+			"responsedata": {
+			  "apisessionid": "thisisasessionid"
+			}
+		  }`)
+	})
+
+	err := client.Login("password", WithClientRequestID("req-42"))
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestLogout(t *testing.T) {
 	setup()
 	defer teardown()
@@ -303,4 +334,5 @@ func TestUpdateDNSRecords(t *testing.T) {
 	if !reflect.DeepEqual(wantRecords, records) {
 		t.Error("UpdateRecords did not return the expected records")
 	}
-}
\ No newline at end of file
+}
+
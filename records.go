@@ -0,0 +1,157 @@
+package netcup
+
+import "fmt"
+
+// FindRecords returns the records of a domain matching hostname and
+// recordType. Either may be left empty to match any value.
+func (c *Client) FindRecords(domain, hostname, recordType string) ([]Record, error) {
+	records, err := c.GetRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Record
+	for _, r := range records {
+		if hostname != "" && r.Hostname != hostname {
+			continue
+		}
+		if recordType != "" && r.Type != recordType {
+			continue
+		}
+		matches = append(matches, r)
+	}
+
+	return matches, nil
+}
+
+// AddRecord creates a new DNS record for a domain and returns it as stored by
+// the server, including its assigned ID.
+func (c *Client) AddRecord(domain string, r Record) (*Record, error) {
+	r.ID = ""
+	r.DeleteRecord = false
+
+	updated, err := c.UpdateRecords(domain, []Record{r})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range updated {
+		if updated[i].Hostname == r.Hostname && updated[i].Type == r.Type && updated[i].Destination == r.Destination {
+			return &updated[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("netcup: created record for hostname %q not found in updated zone", r.Hostname)
+}
+
+// DeleteRecord removes a single DNS record of a domain, identified by its ID.
+func (c *Client) DeleteRecord(domain string, id string) error {
+	records, err := c.GetRecords(domain)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if r.ID != id {
+			continue
+		}
+
+		r.DeleteRecord = true
+		_, err := c.UpdateRecords(domain, []Record{r})
+		return err
+	}
+
+	return fmt.Errorf("netcup: record %q not found", id)
+}
+
+// ReplaceRecords brings a domain's DNS records in line with desired. It
+// diffs desired against the current zone and submits only the changed
+// subset: records missing on the server are created, records matched on
+// both sides are updated in place if they differ, and server records with
+// no match in desired are marked for deletion.
+//
+// Records are matched on (hostname, type, destination) first, so that
+// multiple records sharing a hostname and type - e.g. the two
+// _acme-challenge TXT records a wildcard+apex certificate needs at once -
+// are paired up by value rather than by list position. Anything left over
+// after that (a genuine value change, or a new record) falls back to a
+// positional match on (hostname, type).
+func (c *Client) ReplaceRecords(domain string, desired []Record) ([]Record, error) {
+	current, err := c.GetRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make([]Record, len(current))
+	copy(remaining, current)
+
+	var changes, unmatched []Record
+
+	for _, want := range desired {
+		matched := false
+
+		for i, have := range remaining {
+			if have.Hostname != want.Hostname || have.Type != want.Type || have.Destination != want.Destination {
+				continue
+			}
+
+			want.ID = have.ID
+			if recordChanged(want, have) {
+				changes = append(changes, want)
+			}
+
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			matched = true
+			break
+		}
+
+		if !matched {
+			unmatched = append(unmatched, want)
+		}
+	}
+
+	for _, want := range unmatched {
+		matched := false
+
+		for i, have := range remaining {
+			if have.Hostname != want.Hostname || have.Type != want.Type {
+				continue
+			}
+
+			want.ID = have.ID
+			changes = append(changes, want)
+
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			matched = true
+			break
+		}
+
+		if !matched {
+			want.ID = ""
+			changes = append(changes, want)
+		}
+	}
+
+	for _, obsolete := range remaining {
+		obsolete.DeleteRecord = true
+		changes = append(changes, obsolete)
+	}
+
+	if len(changes) == 0 {
+		return current, nil
+	}
+
+	return c.UpdateRecords(domain, changes)
+}
+
+// recordChanged reports whether a and b differ in a field a caller is
+// expected to set. State is excluded: it is assigned by the server and a
+// desired record built from scratch (as by an ACME/DNS-01 client) leaves it
+// zero-valued, which must not be mistaken for a real change.
+func recordChanged(a, b Record) bool {
+	return a.Hostname != b.Hostname ||
+		a.Type != b.Type ||
+		a.Priority != b.Priority ||
+		a.Destination != b.Destination ||
+		a.DeleteRecord != b.DeleteRecord
+}
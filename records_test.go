@@ -0,0 +1,353 @@
+package netcup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// actionOf peeks at the "action" field of a request body without consuming it.
+func actionOf(t *testing.T, body []byte) string {
+	var req struct {
+		Action string `json:"action"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Error(err)
+	}
+	return req.Action
+}
+
+func TestFindRecords(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"serverrequestid": "",
+			"clientrequestid": "",
+			"action": "infoDnsRecords",
+			"status": "success",
+			"statuscode": 2000,
+			"shortmessage": "DNS records found",
+			"longmessage": "DNS Records for this zone were found.",
+			"responsedata": {
+			  "dnsrecords": [
+				{"id": "123451", "hostname": "@", "type": "A", "priority": "0", "destination": "127.0.0.1", "deleterecord": false, "state": "yes"},
+				{"id": "123452", "hostname": "@", "type": "MX", "priority": "10", "destination": "mail.example.com", "deleterecord": false, "state": "yes"},
+				{"id": "123453", "hostname": "mail", "type": "A", "priority": "0", "destination": "127.0.0.1", "deleterecord": false, "state": "yes"}
+			  ]
+			}
+		  }`)
+	})
+
+	matches, err := client.FindRecords("example.com", "mail", "A")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []Record{
+		{ID: "123453", Hostname: "mail", Type: "A", Priority: "0", Destination: "127.0.0.1", DeleteRecord: false, State: "yes"},
+	}
+
+	if !reflect.DeepEqual(want, matches) {
+		t.Error("FindRecords did not return the expected records")
+	}
+}
+
+func TestAddRecord(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"serverrequestid": "",
+			"clientrequestid": "",
+			"action": "updateDnsRecords",
+			"status": "success",
+			"statuscode": 2000,
+			"shortmessage": "DNS records successful updated",
+			"longmessage": "The given DNS records for this zone were updated.",
+			"responsedata": {
+			  "dnsrecords": [
+				{"id": "123451", "hostname": "@", "type": "A", "priority": "0", "destination": "127.0.0.1", "deleterecord": false, "state": "yes"},
+				{"id": "123456", "hostname": "@", "type": "TXT", "priority": "0", "destination": "test", "deleterecord": false, "state": "yes"}
+			  ]
+			}
+		  }`)
+	})
+
+	added, err := client.AddRecord("example.com", Record{Hostname: "@", Type: "TXT", Priority: "0", Destination: "test", State: "yes"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := &Record{ID: "123456", Hostname: "@", Type: "TXT", Priority: "0", Destination: "test", DeleteRecord: false, State: "yes"}
+
+	if !reflect.DeepEqual(want, added) {
+		t.Error("AddRecord did not return the created record")
+	}
+}
+
+func TestDeleteRecord(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		switch actionOf(t, body) {
+		case "infoDnsRecords":
+			fmt.Fprint(w, `{
+				"serverrequestid": "",
+				"clientrequestid": "",
+				"action": "infoDnsRecords",
+				"status": "success",
+				"statuscode": 2000,
+				"shortmessage": "DNS records found",
+				"longmessage": "DNS Records for this zone were found.",
+				"responsedata": {
+				  "dnsrecords": [
+					{"id": "123451", "hostname": "@", "type": "A", "priority": "0", "destination": "127.0.0.1", "deleterecord": false, "state": "yes"}
+				  ]
+				}
+			  }`)
+		case "updateDnsRecords":
+			want := `{"action":"updateDnsRecords","param":{"apikey":"key","apisessionid":"thisisasessionid","customernumber":1234,"dnsrecordset":{"dnsrecords":[{"deleterecord":true,"destination":"127.0.0.1","hostname":"@","id":"123451","priority":"0","state":"yes","type":"A"}]},"domainname":"example.com"}}`
+			if string(body) != want {
+				t.Error("Client did not send correct updateDnsRecords request for deletion.")
+			}
+
+			fmt.Fprint(w, `{
+				"serverrequestid": "",
+				"clientrequestid": "",
+				"action": "updateDnsRecords",
+				"status": "success",
+				"statuscode": 2000,
+				"shortmessage": "DNS records successful updated",
+				"longmessage": "The given DNS records for this zone were updated.",
+				"responsedata": {
+				  "dnsrecords": []
+				}
+			  }`)
+		default:
+			t.Errorf("unexpected action %q", actionOf(t, body))
+		}
+	})
+
+	if err := client.DeleteRecord("example.com", "123451"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReplaceRecords(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		switch actionOf(t, body) {
+		case "infoDnsRecords":
+			fmt.Fprint(w, `{
+				"serverrequestid": "",
+				"clientrequestid": "",
+				"action": "infoDnsRecords",
+				"status": "success",
+				"statuscode": 2000,
+				"shortmessage": "DNS records found",
+				"longmessage": "DNS Records for this zone were found.",
+				"responsedata": {
+				  "dnsrecords": [
+					{"id": "123451", "hostname": "@", "type": "A", "priority": "0", "destination": "127.0.0.1", "deleterecord": false, "state": "yes"},
+					{"id": "123452", "hostname": "_acme-challenge", "type": "TXT", "priority": "0", "destination": "old-token", "deleterecord": false, "state": "yes"}
+				  ]
+				}
+			  }`)
+		case "updateDnsRecords":
+			fmt.Fprint(w, `{
+				"serverrequestid": "",
+				"clientrequestid": "",
+				"action": "updateDnsRecords",
+				"status": "success",
+				"statuscode": 2000,
+				"shortmessage": "DNS records successful updated",
+				"longmessage": "The given DNS records for this zone were updated.",
+				"responsedata": {
+				  "dnsrecords": [
+					{"id": "123451", "hostname": "@", "type": "A", "priority": "0", "destination": "127.0.0.1", "deleterecord": false, "state": "yes"},
+					{"id": "123452", "hostname": "_acme-challenge", "type": "TXT", "priority": "0", "destination": "new-token", "deleterecord": false, "state": "yes"}
+				  ]
+				}
+			  }`)
+		default:
+			t.Errorf("unexpected action %q", actionOf(t, body))
+		}
+	})
+
+	desired := []Record{
+		{Hostname: "@", Type: "A", Priority: "0", Destination: "127.0.0.1", State: "yes"},
+		{Hostname: "_acme-challenge", Type: "TXT", Priority: "0", Destination: "new-token", State: "yes"},
+	}
+
+	records, err := client.ReplaceRecords("example.com", desired)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []Record{
+		{ID: "123451", Hostname: "@", Type: "A", Priority: "0", Destination: "127.0.0.1", DeleteRecord: false, State: "yes"},
+		{ID: "123452", Hostname: "_acme-challenge", Type: "TXT", Priority: "0", Destination: "new-token", DeleteRecord: false, State: "yes"},
+	}
+
+	if !reflect.DeepEqual(want, records) {
+		t.Error("ReplaceRecords did not return the expected records")
+	}
+}
+
+func TestReplaceRecordsOmitsUnchangedRecord(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		switch actionOf(t, body) {
+		case "infoDnsRecords":
+			fmt.Fprint(w, `{
+				"serverrequestid": "",
+				"clientrequestid": "",
+				"action": "infoDnsRecords",
+				"status": "success",
+				"statuscode": 2000,
+				"shortmessage": "DNS records found",
+				"longmessage": "DNS Records for this zone were found.",
+				"responsedata": {
+				  "dnsrecords": [
+					{"id": "123451", "hostname": "@", "type": "A", "priority": "0", "destination": "127.0.0.1", "deleterecord": false, "state": "yes"},
+					{"id": "123452", "hostname": "_acme-challenge", "type": "TXT", "priority": "0", "destination": "old-token", "deleterecord": false, "state": "yes"}
+				  ]
+				}
+			  }`)
+		case "updateDnsRecords":
+			// The "@"/A record is unchanged from the server's state and must not
+			// be resent, even though desired leaves its (server-assigned) State
+			// zero-valued rather than echoing back "yes".
+			want := `{"action":"updateDnsRecords","param":{"apikey":"key","apisessionid":"thisisasessionid","customernumber":1234,"dnsrecordset":{"dnsrecords":[{"deleterecord":false,"destination":"new-token","hostname":"_acme-challenge","id":"123452","priority":"0","state":"","type":"TXT"}]},"domainname":"example.com"}}`
+			if string(body) != want {
+				t.Errorf("ReplaceRecords submitted more than the minimal diff.\ngot:  %s\nwant: %s", body, want)
+			}
+
+			fmt.Fprint(w, `{
+				"serverrequestid": "",
+				"clientrequestid": "",
+				"action": "updateDnsRecords",
+				"status": "success",
+				"statuscode": 2000,
+				"shortmessage": "DNS records successful updated",
+				"longmessage": "The given DNS records for this zone were updated.",
+				"responsedata": {
+				  "dnsrecords": [
+					{"id": "123451", "hostname": "@", "type": "A", "priority": "0", "destination": "127.0.0.1", "deleterecord": false, "state": "yes"},
+					{"id": "123452", "hostname": "_acme-challenge", "type": "TXT", "priority": "0", "destination": "new-token", "deleterecord": false, "state": "yes"}
+				  ]
+				}
+			  }`)
+		default:
+			t.Errorf("unexpected action %q", actionOf(t, body))
+		}
+	})
+
+	desired := []Record{
+		{Hostname: "@", Type: "A", Priority: "0", Destination: "127.0.0.1"},
+		{Hostname: "_acme-challenge", Type: "TXT", Priority: "0", Destination: "new-token"},
+	}
+
+	if _, err := client.ReplaceRecords("example.com", desired); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReplaceRecordsMatchesSameHostnameAndTypeByDestination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		switch actionOf(t, body) {
+		case "infoDnsRecords":
+			fmt.Fprint(w, `{
+				"serverrequestid": "",
+				"clientrequestid": "",
+				"action": "infoDnsRecords",
+				"status": "success",
+				"statuscode": 2000,
+				"shortmessage": "DNS records found",
+				"longmessage": "DNS Records for this zone were found.",
+				"responsedata": {
+				  "dnsrecords": [
+					{"id": "123451", "hostname": "_acme-challenge", "type": "TXT", "priority": "0", "destination": "apex-token", "deleterecord": false, "state": "yes"},
+					{"id": "123452", "hostname": "_acme-challenge", "type": "TXT", "priority": "0", "destination": "wildcard-token", "deleterecord": false, "state": "yes"}
+				  ]
+				}
+			  }`)
+		case "updateDnsRecords":
+			t.Errorf("ReplaceRecords submitted an update when the two _acme-challenge TXT records already matched desired by value: %s", body)
+		default:
+			t.Errorf("unexpected action %q", actionOf(t, body))
+		}
+	})
+
+	// Same two records the server already has, but listed in the opposite
+	// order - a naive positional match would pair wildcard-token against
+	// apex-token (and vice versa) and submit two spurious updates.
+	desired := []Record{
+		{Hostname: "_acme-challenge", Type: "TXT", Priority: "0", Destination: "wildcard-token"},
+		{Hostname: "_acme-challenge", Type: "TXT", Priority: "0", Destination: "apex-token"},
+	}
+
+	records, err := client.ReplaceRecords("example.com", desired)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []Record{
+		{ID: "123451", Hostname: "_acme-challenge", Type: "TXT", Priority: "0", Destination: "apex-token", DeleteRecord: false, State: "yes"},
+		{ID: "123452", Hostname: "_acme-challenge", Type: "TXT", Priority: "0", Destination: "wildcard-token", DeleteRecord: false, State: "yes"},
+	}
+
+	if !reflect.DeepEqual(want, records) {
+		t.Error("ReplaceRecords did not return the current records unchanged")
+	}
+}
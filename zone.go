@@ -0,0 +1,80 @@
+package netcup
+
+import "context"
+
+// A Zone holds the SOA-level settings of a domain's DNS zone.
+type Zone struct {
+	Name         string `json:"name"`
+	TTL          string `json:"ttl"`
+	Serial       string `json:"serial"`
+	Refresh      string `json:"refresh"`
+	Retry        string `json:"retry"`
+	Expire       string `json:"expire"`
+	DNSSecStatus bool   `json:"dnssecstatus"`
+}
+
+// InfoDNSZoneRequest is the parameter set for the "infoDnsZone" action.
+type InfoDNSZoneRequest struct {
+	DomainName      string `json:"domainname"`
+	ClientRequestID string `json:"clientrequestid,omitempty"`
+}
+
+// UpdateDNSZoneRequest is the parameter set for the "updateDnsZone" action.
+type UpdateDNSZoneRequest struct {
+	DomainName      string `json:"domainname"`
+	Zone            Zone   `json:"dnszone"`
+	ClientRequestID string `json:"clientrequestid,omitempty"`
+}
+
+// GetZoneContext is like GetZone but observes ctx for cancellation and timeouts.
+func (c *Client) GetZoneContext(ctx context.Context, domainname string, opts ...RequestOption) (*Zone, error) {
+	param := InfoDNSZoneRequest{
+		DomainName:      domainname,
+		ClientRequestID: resolveRequestOptions(opts).clientRequestID,
+	}
+
+	buf, err := c.request(ctx, "infoDnsZone", param)
+	if err != nil {
+		return nil, err
+	}
+
+	zone := Zone{}
+	err = unmarshalResponseData(buf, &zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+// GetZone returns the zone settings (TTL, SOA parameters, DNSSEC status) of a domain
+func (c *Client) GetZone(domainname string, opts ...RequestOption) (*Zone, error) {
+	return c.GetZoneContext(context.Background(), domainname, opts...)
+}
+
+// UpdateZoneContext is like UpdateZone but observes ctx for cancellation and timeouts.
+func (c *Client) UpdateZoneContext(ctx context.Context, domainname string, zone Zone, opts ...RequestOption) (*Zone, error) {
+	param := UpdateDNSZoneRequest{
+		DomainName:      domainname,
+		Zone:            zone,
+		ClientRequestID: resolveRequestOptions(opts).clientRequestID,
+	}
+
+	buf, err := c.request(ctx, "updateDnsZone", param)
+	if err != nil {
+		return nil, err
+	}
+
+	responseZone := Zone{}
+	err = unmarshalResponseData(buf, &responseZone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responseZone, nil
+}
+
+// UpdateZone updates the zone settings (TTL, SOA parameters, DNSSEC status) of a domain
+func (c *Client) UpdateZone(domainname string, zone Zone, opts ...RequestOption) (*Zone, error) {
+	return c.UpdateZoneContext(context.Background(), domainname, zone, opts...)
+}
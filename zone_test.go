@@ -0,0 +1,188 @@
+package netcup
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestInfoDNSZone(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// fake login
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		want := `{"action":"infoDnsZone","param":{"apikey":"key","apisessionid":"thisisasessionid","customernumber":1234,"domainname":"example.com"}}`
+		body, err := ioutil.ReadAll(r.Body)
+
+		if err != nil || string(body) != want {
+			t.Error("Client did not send correct infoDnsZone request.")
+		}
+
+		fmt.Fprint(w, `{
+			"serverrequestid": "",
+			"clientrequestid": "",
+			"action": "infoDnsZone",
+			"status": "success",
+			"statuscode": 2000,
+			"shortmessage": "DNS zone found",
+			"longmessage": "DNS zone for this domain were found.",
+			"responsedata": {
+			  "name": "example.com",
+			  "ttl": "86400",
+			  "serial": "2020010100",
+			  "refresh": "28800",
+			  "retry": "7200",
+			  "expire": "1209600",
+			  "dnssecstatus": false
+			}
+		  }`)
+	})
+
+	zone, err := client.GetZone("example.com")
+	if err != nil {
+		t.Error(err)
+	}
+
+	wantZone := &Zone{Name: "example.com", TTL: "86400", Serial: "2020010100", Refresh: "28800", Retry: "7200", Expire: "1209600", DNSSecStatus: false}
+
+	if !reflect.DeepEqual(wantZone, zone) {
+		t.Error("GetZone did not return the expected zone")
+	}
+}
+
+func TestUpdateDNSZone(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// fake login
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		want := `{"action":"updateDnsZone","param":{"apikey":"key","apisessionid":"thisisasessionid","customernumber":1234,"dnszone":{"dnssecstatus":false,"expire":"1209600","name":"example.com","refresh":"28800","retry":"7200","serial":"2020010100","ttl":"3600"},"domainname":"example.com"}}`
+		body, err := ioutil.ReadAll(r.Body)
+
+		if err != nil || string(body) != want {
+			t.Error("Client did not send correct updateDnsZone request.")
+		}
+
+		fmt.Fprint(w, `{
+			"serverrequestid": "",
+			"clientrequestid": "",
+			"action": "updateDnsZone",
+			"status": "success",
+			"statuscode": 2000,
+			"shortmessage": "DNS zone successful updated",
+			"longmessage": "The given DNS zone for this domain was updated.",
+			"responsedata": {
+			  "name": "example.com",
+			  "ttl": "3600",
+			  "serial": "2020010100",
+			  "refresh": "28800",
+			  "retry": "7200",
+			  "expire": "1209600",
+			  "dnssecstatus": false
+			}
+		  }`)
+	})
+
+	updateZone := Zone{Name: "example.com", TTL: "3600", Serial: "2020010100", Refresh: "28800", Retry: "7200", Expire: "1209600", DNSSecStatus: false}
+
+	zone, err := client.UpdateZone("example.com", updateZone)
+	if err != nil {
+		t.Error(err)
+	}
+
+	wantZone := &Zone{Name: "example.com", TTL: "3600", Serial: "2020010100", Refresh: "28800", Retry: "7200", Expire: "1209600", DNSSecStatus: false}
+
+	if !reflect.DeepEqual(wantZone, zone) {
+		t.Error("UpdateZone did not return the expected zone")
+	}
+}
+
+func TestGetZoneContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// fake login
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"serverrequestid": "",
+			"clientrequestid": "",
+			"action": "infoDnsZone",
+			"status": "success",
+			"statuscode": 2000,
+			"shortmessage": "DNS zone found",
+			"longmessage": "DNS zone for this domain were found.",
+			"responsedata": {
+			  "name": "example.com",
+			  "ttl": "86400",
+			  "serial": "2020010100",
+			  "refresh": "28800",
+			  "retry": "7200",
+			  "expire": "1209600",
+			  "dnssecstatus": false
+			}
+		  }`)
+	})
+
+	zone, err := client.GetZoneContext(context.Background(), "example.com")
+	if err != nil {
+		t.Error(err)
+	}
+
+	wantZone := &Zone{Name: "example.com", TTL: "86400", Serial: "2020010100", Refresh: "28800", Retry: "7200", Expire: "1209600", DNSSecStatus: false}
+
+	if !reflect.DeepEqual(wantZone, zone) {
+		t.Error("GetZoneContext did not return the expected zone")
+	}
+}
+
+func TestUpdateZoneContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// fake login
+	client.sessionID = "thisisasessionid"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"serverrequestid": "",
+			"clientrequestid": "",
+			"action": "updateDnsZone",
+			"status": "success",
+			"statuscode": 2000,
+			"shortmessage": "DNS zone successful updated",
+			"longmessage": "The given DNS zone for this domain was updated.",
+			"responsedata": {
+			  "name": "example.com",
+			  "ttl": "3600",
+			  "serial": "2020010100",
+			  "refresh": "28800",
+			  "retry": "7200",
+			  "expire": "1209600",
+			  "dnssecstatus": false
+			}
+		  }`)
+	})
+
+	updateZone := Zone{Name: "example.com", TTL: "3600", Serial: "2020010100", Refresh: "28800", Retry: "7200", Expire: "1209600", DNSSecStatus: false}
+
+	zone, err := client.UpdateZoneContext(context.Background(), "example.com", updateZone)
+	if err != nil {
+		t.Error(err)
+	}
+
+	wantZone := &Zone{Name: "example.com", TTL: "3600", Serial: "2020010100", Refresh: "28800", Retry: "7200", Expire: "1209600", DNSSecStatus: false}
+
+	if !reflect.DeepEqual(wantZone, zone) {
+		t.Error("UpdateZoneContext did not return the expected zone")
+	}
+}